@@ -0,0 +1,99 @@
+package netstorage
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHashUploadSourceComputesDefaultSHA256(t *testing.T) {
+	src := bytes.NewReader([]byte("hello world"))
+	var opts UploadOptions
+
+	if err := _hashUploadSource(src, &opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if opts.Size != int64(len("hello world")) {
+		t.Errorf("unexpected size: %d", opts.Size)
+	}
+	const want = "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+	if opts.SHA256 != want {
+		t.Errorf("unexpected sha256: %s", opts.SHA256)
+	}
+
+	// src must be rewound so the same bytes can be sent on the wire.
+	rest, err := ioutil.ReadAll(src)
+	if err != nil {
+		t.Fatalf("unexpected error reading rewound source: %v", err)
+	}
+	if string(rest) != "hello world" {
+		t.Errorf("expected source to be rewound, got %q", rest)
+	}
+}
+
+func TestHashUploadSourceHonorsCallerSuppliedDigest(t *testing.T) {
+	src := bytes.NewReader([]byte("hello world"))
+	opts := UploadOptions{MD5: "5eb63bbbe01eeed093cb22bb8f5acdc3"}
+
+	if err := _hashUploadSource(src, &opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if opts.SHA256 != "" {
+		t.Errorf("expected sha256 to stay blank when caller supplied md5, got %s", opts.SHA256)
+	}
+	if opts.Size != int64(len("hello world")) {
+		t.Errorf("expected size to still be computed, got %d", opts.Size)
+	}
+}
+
+func TestHashUploadSourceSkipsWorkWhenFullySpecified(t *testing.T) {
+	src := bytes.NewReader([]byte("hello world"))
+	opts := UploadOptions{Size: 11, SHA256: "deadbeef"}
+
+	if err := _hashUploadSource(src, &opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.SHA256 != "deadbeef" {
+		t.Errorf("expected caller-supplied digest to be untouched, got %s", opts.SHA256)
+	}
+}
+
+func TestUploadAction(t *testing.T) {
+	action := _uploadAction(UploadOptions{Size: 11, SHA256: "abc", MTime: 42, IndexZip: true})
+	for _, want := range []string{"upload", "size=11", "sha256=abc", "mtime=42", "index-zip=1"} {
+		if !strings.Contains(action, want) {
+			t.Errorf("expected action %q to contain %q", action, want)
+		}
+	}
+}
+
+func TestUploadContentWithOptionsSignsDigestIntoAction(t *testing.T) {
+	var gotAction string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAction = r.Header.Get("X-Akamai-ACS-Action")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ns := NewNetstorage(server.Listener.Addr().String(), "key1", "secret", false)
+
+	_, _, opts, err := ns.UploadContentWithOptions(nil, bytes.NewReader([]byte("hello world")), "/123456/example/file.txt", UploadOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if opts.SHA256 == "" {
+		t.Fatal("expected a computed sha256 digest")
+	}
+	if !strings.Contains(gotAction, "sha256="+opts.SHA256) {
+		t.Errorf("expected action to carry the digest, got %s", gotAction)
+	}
+	if !strings.Contains(gotAction, "size=11") {
+		t.Errorf("expected action to carry the size, got %s", gotAction)
+	}
+}
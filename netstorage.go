@@ -31,19 +31,89 @@ type Netstorage struct {
 	Key      string
 	Ssl      string
 	Client   *http.Client
+
+	// RetryPolicy controls the exponential backoff used to retry requests
+	// that fail with a transient error. Defaults to DefaultRetryPolicy().
+	RetryPolicy RetryPolicy
+	// RetryClassifier decides which errors/responses are retried. Defaults
+	// to DefaultRetryClassifier.
+	RetryClassifier RetryClassifier
+	// Logger, when set, is called with details of each retry attempt.
+	Logger func(format string, args ...interface{})
+
+	// UserAgent overrides the default "NetStorageKit-Golang" User-Agent header.
+	UserAgent string
+	// Clock overrides time.Now when generating X-Akamai-ACS-Auth-Data.
+	Clock func() time.Time
+	// Nonce overrides the random nonce generator used in
+	// X-Akamai-ACS-Auth-Data.
+	Nonce func() int
+	// BaseHeaders are applied to every request before the ACS auth headers,
+	// which always take precedence on conflict.
+	BaseHeaders http.Header
 }
 
 // NewNetstorage func creates and initiates Netstorage struct.
 // ssl parameter decides https(true) and http(false) which means "s" and "".
+//
+// Deprecated: use New, which returns an error instead of panicking and
+// accepts Options for the http.Client, User-Agent, clock/nonce and more.
 func NewNetstorage(hostname, keyname, key string, ssl bool) *Netstorage {
-	if hostname == "" || keyname == "" || key == "" {
-		panic("[NetstorageError] You should input netstorage hostname, keyname and key all")
+	ns, err := New(hostname, keyname, key, WithHTTPS(ssl))
+	if err != nil {
+		panic(err)
+	}
+	return ns
+}
+
+// retryPolicy returns ns.RetryPolicy, falling back to DefaultRetryPolicy
+// when the zero value is in place (e.g. a Netstorage built as a struct
+// literal rather than through NewNetstorage).
+func (ns *Netstorage) retryPolicy() RetryPolicy {
+	if ns.RetryPolicy.MaxSleep == 0 && ns.RetryPolicy.MinSleep == 0 {
+		return DefaultRetryPolicy()
+	}
+	return ns.RetryPolicy
+}
+
+// retryClassifier returns ns.RetryClassifier, falling back to
+// DefaultRetryClassifier when unset.
+func (ns *Netstorage) retryClassifier() RetryClassifier {
+	if ns.RetryClassifier == nil {
+		return DefaultRetryClassifier
+	}
+	return ns.RetryClassifier
+}
+
+// logf forwards to ns.Logger if one is configured.
+func (ns *Netstorage) logf(format string, args ...interface{}) {
+	if ns.Logger != nil {
+		ns.Logger(format, args...)
+	}
+}
+
+// now returns ns.Clock(), falling back to time.Now when unset.
+func (ns *Netstorage) now() time.Time {
+	if ns.Clock != nil {
+		return ns.Clock()
+	}
+	return time.Now()
+}
+
+// genNonce returns ns.Nonce(), falling back to a random int when unset.
+func (ns *Netstorage) genNonce() int {
+	if ns.Nonce != nil {
+		return ns.Nonce()
 	}
-	s := ""
-	if ssl {
-		s = "s"
+	return rand.Intn(100000)
+}
+
+// userAgent returns ns.UserAgent, falling back to the kit's default.
+func (ns *Netstorage) userAgent() string {
+	if ns.UserAgent != "" {
+		return ns.UserAgent
 	}
-	return &Netstorage{hostname, keyname, key, s, http.DefaultClient}
+	return "NetStorageKit-Golang"
 }
 
 // Only for upload action. (Used by _request func)
@@ -60,6 +130,37 @@ func _ifUploadAction(kwargs map[string]interface{}) (io.Reader, error) {
 	return data, nil
 }
 
+// _dataFactory returns a func that produces a fresh, rewound body reader for
+// the request, so the retry loop in _request can re-send the same bytes on
+// every attempt. Upload-from-file re-reads the source file each time;
+// content supplied as an io.ReadSeeker is rewound; a plain io.Reader is
+// buffered once since it cannot otherwise be replayed.
+func _dataFactory(kwargs map[string]interface{}) (func() (io.Reader, error), error) {
+	if content, exists := kwargs["content"]; exists {
+		reader := content.(io.Reader)
+		if rs, ok := reader.(io.ReadSeeker); ok {
+			return func() (io.Reader, error) {
+				if _, err := rs.Seek(0, io.SeekStart); err != nil {
+					return nil, err
+				}
+				return rs, nil
+			}, nil
+		}
+
+		buf, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+		return func() (io.Reader, error) {
+			return bytes.NewReader(buf), nil
+		}, nil
+	}
+
+	return func() (io.Reader, error) {
+		return _ifUploadAction(kwargs)
+	}, nil
+}
+
 // Reads http body from response, closes response.Body and
 // returns that string. (Used by _request func)
 func _getBody(kwargs map[string]interface{}, response *http.Response) (string, error) {
@@ -97,9 +198,12 @@ func _getBody(kwargs map[string]interface{}, response *http.Response) (string, e
 // Create the authorization headers with Netstorage struct values then
 // request to the Netstorage hostname, and return the response,
 // the body string and the error.
+//
+// Requests that fail with a transient error (connection errors, HTTP 429,
+// HTTP 5xx) are retried with exponential backoff per ns.RetryPolicy; the
+// auth headers are regenerated on every attempt since the timestamp/nonce
+// in X-Akamai-ACS-Auth-Data is bound into the HMAC signature.
 func (ns *Netstorage) _request(kwargs map[string]interface{}) (*http.Response, string, error) {
-	var err error
-
 	nsPath := kwargs["path"].(string)
 	if u, err := url.Parse(nsPath); strings.HasPrefix(nsPath, "/") && err == nil {
 		nsPath = u.RequestURI()
@@ -107,50 +211,99 @@ func (ns *Netstorage) _request(kwargs map[string]interface{}) (*http.Response, s
 		return nil, "", fmt.Errorf("[Netstorage Error] Invalid netstorage path: %s", nsPath)
 	}
 
-	acsAction := fmt.Sprintf("version=1&action=%s", kwargs["action"].(string))
-	acsAuthData := fmt.Sprintf("5, 0.0.0.0, 0.0.0.0, %d, %d, %s",
-		time.Now().Unix(),
-		rand.Intn(100000),
-		ns.Keyname)
+	dataFn, err := _dataFactory(kwargs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	ctx, hasCtx := kwargs["ctx"].(context.Context)
+	method := kwargs["method"].(string)
+	requestURL := fmt.Sprintf("http%s://%s%s", ns.Ssl, ns.Hostname, nsPath)
 
-	signString := fmt.Sprintf("%s\nx-akamai-acs-action:%s\n", nsPath, acsAction)
-	mac := hmac.New(sha256.New, []byte(ns.Key))
-	mac.Write([]byte(acsAuthData + signString))
-	acsAuthSign := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	policy := ns.retryPolicy()
+	classifier := ns.retryClassifier()
+	pc := newPacer(policy)
 
-	var data io.Reader
-	if _, exists := kwargs["content"]; exists {
-		data = kwargs["content"].(io.Reader)
-	} else {
-		data, err = _ifUploadAction(kwargs)
+	var response *http.Response
+	for attempt := 0; ; attempt++ {
+		data, err := dataFn()
 		if err != nil {
 			return nil, "", err
 		}
-	}
 
-	method := kwargs["method"].(string)
-	url := fmt.Sprintf("http%s://%s%s", ns.Ssl, ns.Hostname, nsPath)
-	var request *http.Request
-	if ctx, ok := kwargs["ctx"].(context.Context); ok {
-		request, err = http.NewRequestWithContext(ctx, method, url, data)
-	} else {
-		request, err = http.NewRequest(method, url, data)
-	}
+		acsAction := fmt.Sprintf("version=1&action=%s", kwargs["action"].(string))
+		acsAuthData := fmt.Sprintf("5, 0.0.0.0, 0.0.0.0, %d, %d, %s",
+			ns.now().Unix(),
+			ns.genNonce(),
+			ns.Keyname)
+
+		signString := fmt.Sprintf("%s\nx-akamai-acs-action:%s\n", nsPath, acsAction)
+		mac := hmac.New(sha256.New, []byte(ns.Key))
+		mac.Write([]byte(acsAuthData + signString))
+		acsAuthSign := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+		var request *http.Request
+		if hasCtx {
+			request, err = http.NewRequestWithContext(ctx, method, requestURL, data)
+		} else {
+			request, err = http.NewRequest(method, requestURL, data)
+		}
+		if err != nil {
+			return nil, "", err
+		}
 
-	if err != nil {
-		return nil, "", err
-	}
+		for key, values := range ns.BaseHeaders {
+			for _, v := range values {
+				request.Header.Add(key, v)
+			}
+		}
 
-	request.Header.Add("X-Akamai-ACS-Action", acsAction)
-	request.Header.Add("X-Akamai-ACS-Auth-Data", acsAuthData)
-	request.Header.Add("X-Akamai-ACS-Auth-Sign", acsAuthSign)
-	request.Header.Add("Accept-Encoding", "identity")
-	request.Header.Add("User-Agent", "NetStorageKit-Golang")
+		request.Header.Set("X-Akamai-ACS-Action", acsAction)
+		request.Header.Set("X-Akamai-ACS-Auth-Data", acsAuthData)
+		request.Header.Set("X-Akamai-ACS-Auth-Sign", acsAuthSign)
+		request.Header.Set("Accept-Encoding", "identity")
+		request.Header.Set("User-Agent", ns.userAgent())
+		if rangeHeader, ok := kwargs["range"].(string); ok {
+			request.Header.Set("Range", rangeHeader)
+		}
 
-	response, err := ns.Client.Do(request)
+		response, err = ns.Client.Do(request)
 
-	if err != nil {
-		return nil, "", err
+		retry, retryAfter := classifier(response, err)
+		if !retry || attempt >= policy.MaxRetries {
+			if err != nil {
+				return nil, "", err
+			}
+			break
+		}
+
+		if response != nil {
+			response.Body.Close()
+		}
+
+		sleepFor := pc.next()
+		if retryAfter > sleepFor {
+			sleepFor = retryAfter
+		}
+		ns.logf("[NetstorageRetry] attempt %d for %s failed (err=%v, resp=%v), retrying in %s", attempt+1, nsPath, err, response, sleepFor)
+
+		timer := time.NewTimer(sleepFor)
+		if hasCtx {
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, "", ctx.Err()
+			case <-timer.C:
+			}
+		} else {
+			<-timer.C
+		}
+	}
+
+	// Streaming actions (Open/OpenRange) hand the still-open body back to
+	// the caller instead of having _getBody drain and close it.
+	if stream, _ := kwargs["stream"].(bool); stream {
+		return response, "", nil
 	}
 
 	defer response.Body.Close()
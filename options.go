@@ -0,0 +1,107 @@
+package netstorage
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Option configures a Netstorage built with New.
+type Option func(*Netstorage)
+
+// WithHTTPClient overrides the http.Client used for requests, which
+// defaults to http.DefaultClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(ns *Netstorage) {
+		ns.Client = client
+	}
+}
+
+// WithHTTPS selects "https" (true) or "http" (false) for requests.
+func WithHTTPS(enabled bool) Option {
+	return func(ns *Netstorage) {
+		ns.Ssl = ""
+		if enabled {
+			ns.Ssl = "s"
+		}
+	}
+}
+
+// WithUserAgent overrides the default "NetStorageKit-Golang" User-Agent header.
+func WithUserAgent(userAgent string) Option {
+	return func(ns *Netstorage) {
+		ns.UserAgent = userAgent
+	}
+}
+
+// WithClock overrides time.Now when generating the X-Akamai-ACS-Auth-Data
+// timestamp, letting tests sign requests with a fixed time.
+func WithClock(clock func() time.Time) Option {
+	return func(ns *Netstorage) {
+		ns.Clock = clock
+	}
+}
+
+// WithNonce overrides the random nonce generator used in
+// X-Akamai-ACS-Auth-Data, letting tests sign requests deterministically.
+func WithNonce(nonce func() int) Option {
+	return func(ns *Netstorage) {
+		ns.Nonce = nonce
+	}
+}
+
+// WithLogger sets the func called with details of each retry attempt.
+func WithLogger(logger func(format string, args ...interface{})) Option {
+	return func(ns *Netstorage) {
+		ns.Logger = logger
+	}
+}
+
+// WithBaseHeaders sets headers applied to every request before the ACS
+// auth headers, which always take precedence on conflict.
+func WithBaseHeaders(headers http.Header) Option {
+	return func(ns *Netstorage) {
+		ns.BaseHeaders = headers
+	}
+}
+
+// WithRetryPolicy overrides the default exponential backoff policy used to
+// retry transient failures.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(ns *Netstorage) {
+		ns.RetryPolicy = policy
+	}
+}
+
+// WithRetryClassifier overrides the default classifier deciding which
+// errors/responses are retried.
+func WithRetryClassifier(classifier RetryClassifier) Option {
+	return func(ns *Netstorage) {
+		ns.RetryClassifier = classifier
+	}
+}
+
+// New creates a Netstorage from the required hostname/keyname/key plus any
+// Options, returning an error instead of panicking on bad input. Hostname
+// format should be "-nsu.akamaihd.net"; don't expose Key on a public
+// repository.
+func New(hostname, keyname, key string, opts ...Option) (*Netstorage, error) {
+	if hostname == "" || keyname == "" || key == "" {
+		return nil, fmt.Errorf("[NetstorageError] You should input netstorage hostname, keyname and key all")
+	}
+
+	ns := &Netstorage{
+		Hostname:        hostname,
+		Keyname:         keyname,
+		Key:             key,
+		Client:          http.DefaultClient,
+		RetryPolicy:     DefaultRetryPolicy(),
+		RetryClassifier: DefaultRetryClassifier,
+	}
+
+	for _, opt := range opts {
+		opt(ns)
+	}
+
+	return ns, nil
+}
@@ -0,0 +1,95 @@
+package netstorage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewRejectsMissingFields(t *testing.T) {
+	if _, err := New("", "keyname", "key"); err == nil {
+		t.Error("expected an error for missing hostname")
+	}
+}
+
+func TestNewStatusDefaultsMatchNewNetstorage(t *testing.T) {
+	ns, err := New("example-nsu.akamaihd.net", "keyname", "key")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ns.Ssl != "" {
+		t.Errorf("expected http by default, got ssl=%q", ns.Ssl)
+	}
+	if ns.Client != http.DefaultClient {
+		t.Error("expected http.DefaultClient by default")
+	}
+}
+
+func TestNewNetstoragePanicsOnMissingFields(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected NewNetstorage to panic on missing fields")
+		}
+	}()
+	NewNetstorage("", "keyname", "key", false)
+}
+
+func TestWithClockAndNonceProduceDeterministicAuthData(t *testing.T) {
+	var gotAuthData string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthData = r.Header.Get("X-Akamai-ACS-Auth-Data")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	fixedTime := time.Unix(1700000000, 0)
+	ns, err := New(server.Listener.Addr().String(), "key1", "secret",
+		WithClock(func() time.Time { return fixedTime }),
+		WithNonce(func() int { return 42 }),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, _, err := ns.Stat("/123456/example"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "5, 0.0.0.0, 0.0.0.0, 1700000000, 42, key1"
+	if gotAuthData != want {
+		t.Errorf("expected deterministic auth data %q, got %q", want, gotAuthData)
+	}
+}
+
+func TestWithUserAgentAndBaseHeaders(t *testing.T) {
+	var gotUserAgent, gotCustom string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserAgent = r.Header.Get("User-Agent")
+		gotCustom = r.Header.Get("X-Custom")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	headers := http.Header{}
+	headers.Set("X-Custom", "hello")
+
+	ns, err := New(server.Listener.Addr().String(), "key1", "secret",
+		WithUserAgent("my-agent/1.0"),
+		WithBaseHeaders(headers),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, _, err := ns.Stat("/123456/example"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotUserAgent != "my-agent/1.0" {
+		t.Errorf("expected overridden user agent, got %q", gotUserAgent)
+	}
+	if gotCustom != "hello" {
+		t.Errorf("expected base header to be sent, got %q", gotCustom)
+	}
+}
@@ -0,0 +1,97 @@
+package netstorage
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how Netstorage retries requests that fail with
+// transient errors such as HTTP 5xx, 429, connection resets and timeouts.
+type RetryPolicy struct {
+	MinSleep      time.Duration // sleep before the first retry
+	MaxSleep      time.Duration // sleep is capped at this value
+	DecayConstant float64       // controls how fast the sleep grows between retries
+	MaxRetries    int           // maximum number of retries after the initial attempt
+}
+
+// DefaultRetryPolicy returns the 10ms->2s exponential backoff used by
+// Netstorage when no RetryPolicy is supplied, modeled after the pacer in
+// the rclone NetStorage backend.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MinSleep:      10 * time.Millisecond,
+		MaxSleep:      2 * time.Second,
+		DecayConstant: 2,
+		MaxRetries:    5,
+	}
+}
+
+// RetryClassifier decides whether a request should be retried given the
+// response and/or error returned by the HTTP client, and how long to wait
+// before the next attempt (zero defers to the pacer's own backoff).
+type RetryClassifier func(resp *http.Response, err error) (retry bool, retryAfter time.Duration)
+
+// DefaultRetryClassifier retries on connection-level errors, HTTP 429 and
+// HTTP 5xx, honoring a Retry-After header when the server sends one.
+func DefaultRetryClassifier(resp *http.Response, err error) (bool, time.Duration) {
+	if err != nil {
+		return true, 0
+	}
+	if resp == nil {
+		return false, 0
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return true, retryAfterDuration(resp)
+	}
+	return false, 0
+}
+
+// retryAfterDuration parses the Retry-After header as either a number of
+// seconds or an HTTP date, returning zero if absent or unparseable.
+func retryAfterDuration(resp *http.Response) time.Duration {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+// pacer paces retried requests using exponential backoff with jitter,
+// capped at policy.MaxSleep.
+type pacer struct {
+	policy RetryPolicy
+	sleep  time.Duration
+}
+
+func newPacer(policy RetryPolicy) *pacer {
+	return &pacer{policy: policy, sleep: policy.MinSleep}
+}
+
+// next returns the jittered duration to sleep before the upcoming retry,
+// then grows the underlying backoff for the attempt after that.
+func (p *pacer) next() time.Duration {
+	d := jitter(p.sleep)
+	p.sleep = time.Duration(float64(p.sleep) * math.Pow(2, 1/p.policy.DecayConstant))
+	if p.sleep > p.policy.MaxSleep {
+		p.sleep = p.policy.MaxSleep
+	}
+	return d
+}
+
+// jitter randomizes a sleep duration to between 50% and 100% of its value
+// to avoid a thundering herd of retries all waking up at once.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2+1)))
+}
@@ -0,0 +1,84 @@
+package netstorage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func fastRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MinSleep:      time.Millisecond,
+		MaxSleep:      5 * time.Millisecond,
+		DecayConstant: 2,
+		MaxRetries:    3,
+	}
+}
+
+func TestRequestRetriesOnServerError(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	ns := NewNetstorage(server.Listener.Addr().String(), "key1", "secret", false)
+	ns.RetryPolicy = fastRetryPolicy()
+
+	_, body, err := ns.Stat("/123456/example")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body != "ok" {
+		t.Errorf("unexpected body: %q", body)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestRequestGivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ns := NewNetstorage(server.Listener.Addr().String(), "key1", "secret", false)
+	ns.RetryPolicy = fastRetryPolicy()
+
+	_, _, err := ns.Stat("/123456/example")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != int32(ns.RetryPolicy.MaxRetries+1) {
+		t.Errorf("expected %d attempts, got %d", ns.RetryPolicy.MaxRetries+1, got)
+	}
+}
+
+func TestRequestDoesNotRetryOnSuccess(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ns := NewNetstorage(server.Listener.Addr().String(), "key1", "secret", false)
+	ns.RetryPolicy = fastRetryPolicy()
+
+	if _, _, err := ns.Stat("/123456/example"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("expected 1 attempt, got %d", got)
+	}
+}
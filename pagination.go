@@ -0,0 +1,95 @@
+package netstorage
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// walkPageSize is the max_entries used by Walk between DirPage calls. A
+// var, not a const, so tests can shrink it to exercise pagination without
+// needing thousands of fixture entries.
+var walkPageSize = 1000
+
+// DirOptions configures a paginated "dir" listing.
+type DirOptions struct {
+	MaxEntries int    // caps the number of entries Akamai returns in one response
+	Start      string // resume listing after this path (the continuation cursor)
+	End        string // stop listing at this path
+	Prefix     string // only list entries whose name starts with this prefix
+}
+
+// DirPage is one page of a "dir" listing. NextStart is empty once the
+// listing is exhausted; otherwise pass it as DirOptions.Start to fetch the
+// next page.
+type DirPage struct {
+	Directory string
+	Entries   []DirEntry
+	NextStart string
+}
+
+// DirPage issues a single paginated "dir" call and returns the entries
+// Akamai returned along with a cursor for the next page. Large directories
+// are truncated by Akamai at MaxEntries (or its own response-size limits);
+// Walk follows NextStart automatically so callers don't have to.
+func (ns *Netstorage) DirPage(ctx context.Context, nsPath string, opts DirOptions) (*DirPage, error) {
+	action := "dir&format=xml"
+	if opts.MaxEntries > 0 {
+		action += fmt.Sprintf("&max_entries=%d", opts.MaxEntries)
+	}
+	if opts.Start != "" {
+		action += "&start=" + url.QueryEscape(opts.Start)
+	}
+	if opts.End != "" {
+		action += "&end=" + url.QueryEscape(opts.End)
+	}
+	if opts.Prefix != "" {
+		action += "&prefix=" + url.QueryEscape(opts.Prefix)
+	}
+
+	_, body, err := ns._request(map[string]interface{}{
+		"action": action,
+		"method": "GET",
+		"path":   nsPath,
+		"ctx":    ctx,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	listing, err := ParseDirListing(body)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &DirPage{
+		Directory: listing.Directory,
+		Entries:   listing.Files,
+		NextStart: listing.Resume.Start,
+	}
+	return page, nil
+}
+
+// Walk calls fn for every entry in nsPath, transparently following
+// DirPage's continuation cursor until the listing is exhausted. Walk stops
+// and returns fn's error as soon as fn returns one.
+func (ns *Netstorage) Walk(ctx context.Context, nsPath string, fn func(entry DirEntry) error) error {
+	opts := DirOptions{MaxEntries: walkPageSize}
+	for {
+		page, err := ns.DirPage(ctx, nsPath, opts)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range page.Entries {
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+
+		if page.NextStart == "" {
+			return nil
+		}
+		opts.Start = page.NextStart
+	}
+}
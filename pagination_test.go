@@ -0,0 +1,179 @@
+package netstorage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// requestedStart extracts the "start" parameter NetStorageKit sent, which
+// travels inside the X-Akamai-ACS-Action header (not the request URL) since
+// every action is encoded as a single query-string-shaped header value.
+func requestedStart(r *http.Request) string {
+	values, err := url.ParseQuery(r.Header.Get("X-Akamai-ACS-Action"))
+	if err != nil {
+		return ""
+	}
+	return values.Get("start")
+}
+
+// dirPageFixture renders a "dir" response. resume, when non-empty, is the
+// full path Akamai would put in the <resume start="…"/> continuation
+// element; pass "" to render a non-truncated listing.
+func dirPageFixture(dir string, names []string, resume string) string {
+	xml := fmt.Sprintf(`<list directory="%s">`, dir)
+	for _, n := range names {
+		xml += fmt.Sprintf(`<file type="file" name="%s" size="1" />`, n)
+	}
+	if resume != "" {
+		xml += fmt.Sprintf(`<resume start="%s" />`, resume)
+	}
+	xml += `</list>`
+	return xml
+}
+
+func TestDirPageNoNextStartWhenResponseOmitsResume(t *testing.T) {
+	// A directory holding exactly MaxEntries entries and nothing more: the
+	// server doesn't emit <resume>, so NextStart must stay empty rather
+	// than being guessed from len(Files) == MaxEntries (which would send
+	// one spurious extra request and, with an inclusive start, re-emit the
+	// boundary entry).
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(dirPageFixture("/123456/example", []string{"a", "b"}, "")))
+	}))
+	defer server.Close()
+
+	ns := NewNetstorage(server.Listener.Addr().String(), "key1", "secret", false)
+
+	page, err := ns.DirPage(context.Background(), "/123456/example", DirOptions{MaxEntries: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.NextStart != "" {
+		t.Errorf("expected no NextStart when the response omits <resume>, got %q", page.NextStart)
+	}
+}
+
+func TestDirPageUsesResumeElementEvenBelowMaxEntries(t *testing.T) {
+	// Akamai can also truncate on its own response-size limits and return
+	// fewer than MaxEntries while still emitting <resume>; NextStart must
+	// be driven by that element, not by comparing counts.
+	const cursor = "/123456/example/a dir/b.txt"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(dirPageFixture("/123456/example", []string{"a"}, cursor)))
+	}))
+	defer server.Close()
+
+	ns := NewNetstorage(server.Listener.Addr().String(), "key1", "secret", false)
+
+	page, err := ns.DirPage(context.Background(), "/123456/example", DirOptions{MaxEntries: 50})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.NextStart != cursor {
+		t.Errorf("expected NextStart to be the <resume> element's full path %q, got %q", cursor, page.NextStart)
+	}
+}
+
+func TestWalkRoundTripsFullPathCursorThroughStartParam(t *testing.T) {
+	// Entries are keyed by their full netstorage path (including a space,
+	// to force URL-encoding), not by basename, so a server that hands back
+	// a full-path <resume> cursor and expects that exact path on the next
+	// request's "start=" param will fail the test if the client mangles it
+	// into a basename or drops it.
+	const dir = "/123456/example"
+	entries := []string{"alpha", "beta", "gamma delta", "epsilon"}
+	cursorFor := func(name string) string { return dir + "/" + name }
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := requestedStart(r)
+		requests = append(requests, start)
+
+		idx := 0
+		if start != "" {
+			for i, name := range entries {
+				if cursorFor(name) == start {
+					idx = i + 1
+					break
+				}
+			}
+		}
+
+		end := idx + 2
+		if end > len(entries) {
+			end = len(entries)
+		}
+		page := entries[idx:end]
+
+		resume := ""
+		if end < len(entries) {
+			resume = cursorFor(page[len(page)-1])
+		}
+
+		w.Write([]byte(dirPageFixture(dir, page, resume)))
+	}))
+	defer server.Close()
+
+	ns := NewNetstorage(server.Listener.Addr().String(), "key1", "secret", false)
+
+	origPageSize := walkPageSize
+	walkPageSize = 2
+	defer func() { walkPageSize = origPageSize }()
+
+	var seen []string
+	err := ns.Walk(context.Background(), dir, func(entry DirEntry) error {
+		seen = append(seen, entry.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(seen) != len(entries) {
+		t.Fatalf("expected %v, got %v", entries, seen)
+	}
+	for i := range entries {
+		if seen[i] != entries[i] {
+			t.Fatalf("expected %v, got %v", entries, seen)
+		}
+	}
+
+	wantRequests := []string{"", cursorFor("beta")}
+	if len(requests) != len(wantRequests) {
+		t.Fatalf("expected start params %v, got %v", wantRequests, requests)
+	}
+	for i, want := range wantRequests {
+		if requests[i] != want {
+			t.Errorf("request %d: expected decoded start=%q, got %q", i, want, requests[i])
+		}
+	}
+}
+
+func TestWalkStopsOnCallbackError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(dirPageFixture("/123456/example", []string{"a", "b", "c"}, "")))
+	}))
+	defer server.Close()
+
+	ns := NewNetstorage(server.Listener.Addr().String(), "key1", "secret", false)
+
+	stop := fmt.Errorf("stop")
+	var seen []string
+	err := ns.Walk(context.Background(), "/123456/example", func(entry DirEntry) error {
+		seen = append(seen, entry.Name)
+		if entry.Name == "b" {
+			return stop
+		}
+		return nil
+	})
+	if err != stop {
+		t.Fatalf("expected stop error, got %v", err)
+	}
+	if len(seen) != 2 {
+		t.Errorf("expected to see 2 entries before stopping, got %v", seen)
+	}
+}
@@ -0,0 +1,49 @@
+package netstorage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Open performs a "download" action and returns the response body unread,
+// so it can be piped into an io.Copy, an HTTP handler, or a hash verifier
+// without ever touching disk. The caller is responsible for closing the
+// returned io.ReadCloser.
+func (ns *Netstorage) Open(ctx context.Context, nsPath string) (io.ReadCloser, *http.Response, error) {
+	return ns.OpenRange(ctx, nsPath, 0, 0)
+}
+
+// OpenRange is like Open but requests a byte range via a "Range" header.
+// A zero offset and length downloads the whole object; a non-zero length
+// requests [offset, offset+length); a zero length with a non-zero offset
+// requests from offset through the end of the object.
+func (ns *Netstorage) OpenRange(ctx context.Context, nsPath string, offset, length int64) (io.ReadCloser, *http.Response, error) {
+	if strings.HasSuffix(nsPath, "/") {
+		return nil, nil, fmt.Errorf("[NetstorageError] Nestorage download path shouldn't be a directory: %s", nsPath)
+	}
+
+	kwargs := map[string]interface{}{
+		"action": "download",
+		"method": "GET",
+		"path":   nsPath,
+		"ctx":    ctx,
+		"stream": true,
+	}
+
+	if offset != 0 || length != 0 {
+		if length > 0 {
+			kwargs["range"] = fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+		} else {
+			kwargs["range"] = fmt.Sprintf("bytes=%d-", offset)
+		}
+	}
+
+	response, _, err := ns._request(kwargs)
+	if err != nil {
+		return nil, response, err
+	}
+	return response.Body, response, nil
+}
@@ -0,0 +1,137 @@
+package netstorage
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOpenStreamsPartialReads(t *testing.T) {
+	const want = "the quick brown fox jumps over the lazy dog"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(want))
+	}))
+	defer server.Close()
+
+	ns := NewNetstorage(server.Listener.Addr().String(), "key1", "secret", false)
+
+	body, resp, err := ns.Open(context.Background(), "/123456/example/file.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status: %d", resp.StatusCode)
+	}
+
+	first := make([]byte, 10)
+	if _, err := io.ReadFull(body, first); err != nil {
+		t.Fatalf("unexpected error reading first chunk: %v", err)
+	}
+	if string(first) != want[:10] {
+		t.Errorf("unexpected first chunk: %q", first)
+	}
+
+	rest, err := ioutil.ReadAll(body)
+	if err != nil {
+		t.Fatalf("unexpected error reading rest: %v", err)
+	}
+	if string(rest) != want[10:] {
+		t.Errorf("unexpected remaining bytes: %q", rest)
+	}
+}
+
+func TestOpenEarlyClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("the quick brown fox jumps over the lazy dog"))
+	}))
+	defer server.Close()
+
+	ns := NewNetstorage(server.Listener.Addr().String(), "key1", "secret", false)
+
+	body, _, err := ns.Open(context.Background(), "/123456/example/file.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf := make([]byte, 4)
+	if _, err := io.ReadFull(body, buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := body.Close(); err != nil {
+		t.Errorf("unexpected error closing body early: %v", err)
+	}
+}
+
+func TestOpenRangeSendsRangeHeader(t *testing.T) {
+	var gotRange string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRange = r.Header.Get("Range")
+		w.Write([]byte("ignored"))
+	}))
+	defer server.Close()
+
+	ns := NewNetstorage(server.Listener.Addr().String(), "key1", "secret", false)
+
+	body, _, err := ns.OpenRange(context.Background(), "/123456/example/file.txt", 10, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer body.Close()
+
+	if gotRange != "bytes=10-29" {
+		t.Errorf("unexpected range header: %q", gotRange)
+	}
+}
+
+func TestOpenContextCancellationMidStream(t *testing.T) {
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("first chunk"))
+		w.(http.Flusher).Flush()
+		close(started)
+		<-unblock
+		w.Write([]byte("second chunk"))
+	}))
+	defer server.Close()
+	defer close(unblock)
+
+	ns := NewNetstorage(server.Listener.Addr().String(), "key1", "secret", false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	body, _, err := ns.Open(ctx, "/123456/example/file.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer body.Close()
+
+	buf := make([]byte, len("first chunk"))
+	if _, err := io.ReadFull(body, buf); err != nil {
+		t.Fatalf("unexpected error reading first chunk: %v", err)
+	}
+
+	<-started
+	cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := ioutil.ReadAll(body)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("expected an error reading after context cancellation")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("read did not unblock after context cancellation")
+	}
+}
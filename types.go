@@ -0,0 +1,142 @@
+package netstorage
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+)
+
+// FileType is the kind of object described by a DirEntry, as reported
+// by Akamai's "type" attribute on <file> elements.
+type FileType string
+
+// The file types Akamai NetStorage reports in dir/stat responses.
+const (
+	FileTypeFile    FileType = "file"
+	FileTypeDir     FileType = "dir"
+	FileTypeSymlink FileType = "symlink"
+)
+
+// DirEntry describes a single file, directory or symbolic link as
+// returned by the "dir" and "stat" actions.
+type DirEntry struct {
+	Type     FileType `xml:"type,attr"`
+	Name     string   `xml:"name,attr"`
+	Mtime    int64    `xml:"mtime,attr"`
+	Size     int64    `xml:"size,attr"`
+	MD5      string   `xml:"md5,attr,omitempty"`
+	SHA1     string   `xml:"sha1,attr,omitempty"`
+	SHA256   string   `xml:"sha256,attr,omitempty"`
+	Target   string   `xml:"target,attr,omitempty"` // symlink destination, only set when Type is FileTypeSymlink
+	Implicit bool     `xml:"implicit,attr,omitempty"`
+}
+
+// DirResume is the continuation token Akamai includes in a truncated "dir"
+// response. Start is the full, unescaped path to pass back as the
+// "start=" parameter of the next page (DirPage URL-escapes it when
+// building the action); it is absent when the listing was not truncated.
+type DirResume struct {
+	Start string `xml:"start,attr"`
+}
+
+// DirListing is the parsed response of the "dir" action.
+type DirListing struct {
+	XMLName   xml.Name   `xml:"list"`
+	Directory string     `xml:"directory,attr"`
+	Files     []DirEntry `xml:"file"`
+	Resume    DirResume  `xml:"resume"`
+}
+
+// StatResult is the parsed response of the "stat" action.
+type StatResult struct {
+	XMLName   xml.Name   `xml:"stat"`
+	Directory string     `xml:"directory,attr"`
+	Files     []DirEntry `xml:"file"`
+}
+
+// DuInfo carries the usage totals reported inside a "du" response.
+type DuInfo struct {
+	Files int64 `xml:"files,attr"`
+	Bytes int64 `xml:"bytes,attr"`
+}
+
+// DuResult is the parsed response of the "du" action.
+type DuResult struct {
+	XMLName   xml.Name `xml:"du"`
+	Directory string   `xml:"directory,attr"`
+	Info      DuInfo   `xml:"du-info"`
+}
+
+// ParseDirListing unmarshals the raw XML body returned by the "dir"
+// action into a DirListing.
+func ParseDirListing(body string) (*DirListing, error) {
+	var listing DirListing
+	if err := xml.Unmarshal([]byte(body), &listing); err != nil {
+		return nil, fmt.Errorf("[NetstorageError] failed to parse dir response: %w", err)
+	}
+	return &listing, nil
+}
+
+// ParseStatResult unmarshals the raw XML body returned by the "stat"
+// action into a StatResult.
+func ParseStatResult(body string) (*StatResult, error) {
+	var result StatResult
+	if err := xml.Unmarshal([]byte(body), &result); err != nil {
+		return nil, fmt.Errorf("[NetstorageError] failed to parse stat response: %w", err)
+	}
+	return &result, nil
+}
+
+// ParseDuResult unmarshals the raw XML body returned by the "du"
+// action into a DuResult.
+func ParseDuResult(body string) (*DuResult, error) {
+	var result DuResult
+	if err := xml.Unmarshal([]byte(body), &result); err != nil {
+		return nil, fmt.Errorf("[NetstorageError] failed to parse du response: %w", err)
+	}
+	return &result, nil
+}
+
+// DirParsed returns the directory structure, unmarshaled into a DirListing.
+func (ns *Netstorage) DirParsed(nsPath string) (*DirListing, error) {
+	return ns.DirParsedWithContext(context.Background(), nsPath)
+}
+
+// DirParsedWithContext provides DirParsed behavior with context.
+func (ns *Netstorage) DirParsedWithContext(ctx context.Context, nsPath string) (*DirListing, error) {
+	_, body, err := ns.DirWithContext(ctx, nsPath)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDirListing(body)
+}
+
+// StatParsed returns the information about an object structure, unmarshaled
+// into a StatResult.
+func (ns *Netstorage) StatParsed(nsPath string) (*StatResult, error) {
+	return ns.StatParsedWithContext(context.Background(), nsPath)
+}
+
+// StatParsedWithContext provides StatParsed behavior with context.
+func (ns *Netstorage) StatParsedWithContext(ctx context.Context, nsPath string) (*StatResult, error) {
+	_, body, err := ns.StatWithContext(ctx, nsPath)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStatResult(body)
+}
+
+// DuParsed returns the disk usage information for a directory, unmarshaled
+// into a DuResult.
+func (ns *Netstorage) DuParsed(nsPath string) (*DuResult, error) {
+	return ns.DuParsedWithContext(context.Background(), nsPath)
+}
+
+// DuParsedWithContext provides DuParsed behavior with context.
+func (ns *Netstorage) DuParsedWithContext(ctx context.Context, nsPath string) (*DuResult, error) {
+	_, body, err := ns.DuWithContext(ctx, nsPath)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDuResult(body)
+}
@@ -0,0 +1,83 @@
+package netstorage
+
+import "testing"
+
+const dirFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<list directory="/123456/example">
+  <file type="dir" name="." implicit="true" />
+  <file type="dir" name="images" mtime="1609459200" />
+  <file type="file" name="index.html" mtime="1609459260" size="512" md5="d41d8cd98f00b204e9800998ecf8427e" />
+  <file type="symlink" name="latest" mtime="1609459320" target="index.html" />
+</list>`
+
+const statFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<stat directory="/123456/example/index.html">
+  <file type="file" name="index.html" mtime="1609459260" size="512" md5="d41d8cd98f00b204e9800998ecf8427e" sha1="da39a3ee5e6b4b0d3255bfef95601890afd80709" sha256="e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" />
+</stat>`
+
+const duFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<du directory="/123456/example">
+  <du-info files="42" bytes="104857600" />
+</du>`
+
+func TestParseDirListing(t *testing.T) {
+	listing, err := ParseDirListing(dirFixture)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if listing.Directory != "/123456/example" {
+		t.Errorf("unexpected directory: %s", listing.Directory)
+	}
+	if len(listing.Files) != 4 {
+		t.Fatalf("expected 4 entries, got %d", len(listing.Files))
+	}
+
+	implicit := listing.Files[0]
+	if !implicit.Implicit || implicit.Type != FileTypeDir {
+		t.Errorf("unexpected implicit root entry: %+v", implicit)
+	}
+
+	file := listing.Files[2]
+	if file.Type != FileTypeFile || file.Name != "index.html" || file.Size != 512 {
+		t.Errorf("unexpected file entry: %+v", file)
+	}
+
+	symlink := listing.Files[3]
+	if symlink.Type != FileTypeSymlink || symlink.Target != "index.html" {
+		t.Errorf("unexpected symlink entry: %+v", symlink)
+	}
+}
+
+func TestParseStatResult(t *testing.T) {
+	result, err := ParseStatResult(statFixture)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Files) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(result.Files))
+	}
+
+	file := result.Files[0]
+	if file.MD5 == "" || file.SHA1 == "" || file.SHA256 == "" {
+		t.Errorf("expected all digests to be populated: %+v", file)
+	}
+}
+
+func TestParseDuResult(t *testing.T) {
+	result, err := ParseDuResult(duFixture)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Info.Files != 42 || result.Info.Bytes != 104857600 {
+		t.Errorf("unexpected du info: %+v", result.Info)
+	}
+}
+
+func TestParseDirListingInvalidXML(t *testing.T) {
+	if _, err := ParseDirListing("not xml"); err == nil {
+		t.Error("expected an error for invalid XML")
+	}
+}